@@ -17,9 +17,24 @@ import (
 // This error is then returned when calling either URL or String, which both
 // return the underlying url.URL (its raw form or converted to string), only
 // if there were no errors when building the whole route.
+//
+// AddQuery, AddQueryValues and SetFragment let callers compose the query
+// string and fragment of the route alongside its path, instead of falling
+// back to raw net/url calls once the path is built.
+//
+// Strict opts a route into additional RFC 3986 path-normalization checks on
+// every JoinSegment call made afterwards; see validateStrictSegment.
+//
+// clientRoute methods all take a value receiver, so reusing a shared prefix
+// such as APIv4Route() as the base of several routes is already safe:
+// JoinXxx calls made from one use of the prefix never affect another, or
+// the shared base itself. There is deliberately no Clone method for this;
+// one was added and then removed because it couldn't honor the deep-copy
+// semantics its doc comment promised.
 type clientRoute struct {
-	url url.URL
-	err error
+	url    url.URL
+	err    error
+	strict bool
 }
 
 func newClientRoute(v string) clientRoute {
@@ -28,6 +43,66 @@ func newClientRoute(v string) clientRoute {
 	return r
 }
 
+// newStrictClientRoute is like newClientRoute, but puts the route in strict
+// mode (see Strict) from its very first segment.
+func newStrictClientRoute(v string) clientRoute {
+	r := newClientRoute(v)
+	r.strict = true
+	if err := validateStrictSegment(v); err != nil {
+		r.err = err
+	}
+	return r
+}
+
+// Strict puts r in strict mode: every JoinSegment call made afterwards (and
+// any Normalize call on URL/String) applies the stricter RFC 3986
+// path-normalization rules documented on validateStrictSegment, instead of
+// just rejecting literal slashes.
+func (r clientRoute) Strict() clientRoute {
+	r.strict = true
+	return r
+}
+
+// validateStrictSegment applies the extra checks JoinSegment runs on a
+// segment when the route is in strict mode: "." and ".." are rejected
+// outright, as are segments containing a NUL, CR or LF byte, and the
+// segment is unescaped to make sure it doesn't decode back into a path
+// separator (e.g. a literal "%2F" or "%5C").
+func validateStrictSegment(v string) error {
+	if strings.ContainsAny(v, "\x00\r\n") {
+		return fmt.Errorf("%q contains control characters", v)
+	}
+
+	decoded, err := url.PathUnescape(v)
+	if err != nil {
+		return fmt.Errorf("%q is not properly escaped: %w", v, err)
+	}
+
+	if decoded == "." || decoded == ".." {
+		return fmt.Errorf("%q is not allowed in strict mode", v)
+	}
+	if strings.ContainsAny(decoded, "/\\") {
+		return fmt.Errorf("%q decodes into a path separator", v)
+	}
+	if strings.ContainsAny(decoded, "\x00\r\n") {
+		return fmt.Errorf("%q decodes into control characters", v)
+	}
+
+	return nil
+}
+
+// APIRoute returns the shared "/api" prefix that all API routes are built
+// from, so call sites don't each reconstruct it from scratch.
+func APIRoute() clientRoute {
+	return newClientRoute("api")
+}
+
+// APIv4Route returns the shared "/api/v4" prefix that all API v4 routes are
+// built from.
+func APIv4Route() clientRoute {
+	return APIRoute().JoinSegment("v4")
+}
+
 func (r clientRoute) JoinRoute(newRoute clientRoute) clientRoute {
 	if r.err != nil {
 		return r
@@ -39,6 +114,7 @@ func (r clientRoute) JoinRoute(newRoute clientRoute) clientRoute {
 	}
 
 	r.url = *r.url.JoinPath(newRoute.url.String())
+	r.strict = r.strict || newRoute.strict
 	return r
 }
 
@@ -48,6 +124,13 @@ func (r clientRoute) JoinSegment(v string) clientRoute {
 		return r
 	}
 
+	if r.strict {
+		if err := validateStrictSegment(v); err != nil {
+			r.err = err
+			return r
+		}
+	}
+
 	return r.JoinRoute(newClientRoute(v))
 }
 
@@ -112,18 +195,106 @@ func (r clientRoute) JoinEmojiname(v string) clientRoute {
 	return r.JoinSegment(v)
 }
 
+func (r clientRoute) AddQuery(key, value string) clientRoute {
+	if r.err != nil {
+		return r
+	}
+
+	if key == "" {
+		r.err = fmt.Errorf("query key cannot be empty")
+		return r
+	}
+
+	q := r.url.Query()
+	q.Add(key, value)
+	r.url.RawQuery = q.Encode()
+	return r
+}
+
+func (r clientRoute) AddQueryValues(values url.Values) clientRoute {
+	if r.err != nil {
+		return r
+	}
+
+	q := r.url.Query()
+	for key, vals := range values {
+		if key == "" {
+			r.err = fmt.Errorf("query key cannot be empty")
+			return r
+		}
+		for _, v := range vals {
+			q.Add(key, v)
+		}
+	}
+	r.url.RawQuery = q.Encode()
+	return r
+}
+
+func (r clientRoute) ClearQuery() clientRoute {
+	if r.err != nil {
+		return r
+	}
+
+	r.url.RawQuery = ""
+	return r
+}
+
+func (r clientRoute) SetFragment(fragment string) clientRoute {
+	if r.err != nil {
+		return r
+	}
+
+	r.url.Fragment = fragment
+	return r
+}
+
+// Normalize collapses accidental empty path segments ("//") produced while
+// building the route into a single slash. It is a no-op unless the route is
+// in strict mode (see Strict), and is applied automatically by URL and
+// String.
+func (r clientRoute) Normalize() clientRoute {
+	if !r.strict || r.err != nil {
+		return r
+	}
+
+	r.url.Path = collapseSlashes(r.url.Path)
+	if r.url.RawPath != "" {
+		r.url.RawPath = collapseSlashes(r.url.RawPath)
+	}
+	return r
+}
+
+func collapseSlashes(p string) string {
+	for strings.Contains(p, "//") {
+		p = strings.ReplaceAll(p, "//", "/")
+	}
+	return p
+}
+
+// withJoinedPath returns a copy of r.url with its Path/RawPath normalized to
+// include a leading slash, leaving RawQuery and Fragment untouched.
+func (r clientRoute) withJoinedPath() (url.URL, error) {
+	r = r.Normalize()
+	urlCopy := r.url
+	root := url.URL{Path: "/"}
+	joined := root.JoinPath(r.url.EscapedPath())
+	if joined == nil {
+		return url.URL{}, fmt.Errorf("failed to join path")
+	}
+	urlCopy.Path = joined.Path
+	urlCopy.RawPath = joined.RawPath
+	return urlCopy, nil
+}
+
 func (r clientRoute) URL() (*url.URL, error) {
 	if r.err != nil {
 		return nil, r.err
 	}
 
-	// Make a copy and ensure there is a leading slash
-	urlCopy := r.url
-	path, err := url.JoinPath("/", r.url.String())
+	urlCopy, err := r.withJoinedPath()
 	if err != nil {
 		return nil, err
 	}
-	urlCopy.Path = path
 	return &urlCopy, nil
 }
 
@@ -132,6 +303,20 @@ func (r clientRoute) String() (string, error) {
 		return "", r.err
 	}
 
-	// Make sure that there is a leading slash
-	return url.JoinPath("/", r.url.String())
+	urlCopy, err := r.withJoinedPath()
+	if err != nil {
+		return "", err
+	}
+	return urlCopy.String(), nil
+}
+
+// MustString is like String, but panics if the route failed to build. It's
+// meant for constant, compile-time-known routes, such as those used in
+// tests, analogous to regexp.MustCompile.
+func (r clientRoute) MustString() string {
+	s, err := r.String()
+	if err != nil {
+		panic(err)
+	}
+	return s
 }
@@ -0,0 +1,182 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// routeTemplateValidator validates a single path variable's raw (unescaped)
+// value, and joins it onto a clientRoute using the appropriate JoinXxx
+// method so that Build and Match share the exact same validation rules.
+type routeTemplateValidator struct {
+	isValid func(string) bool
+	join    func(clientRoute, string) clientRoute
+}
+
+var routeTemplateValidators = map[string]routeTemplateValidator{
+	"id": {
+		isValid: IsValidId,
+		join:    clientRoute.JoinId,
+	},
+	"username": {
+		isValid: IsValidUsername,
+		join:    clientRoute.JoinUsername,
+	},
+	"teamname": {
+		isValid: IsValidTeamName,
+		join:    clientRoute.JoinTeamname,
+	},
+	"channelname": {
+		isValid: IsValidChannelIdentifier,
+		join:    clientRoute.JoinChannelname,
+	},
+	"email": {
+		isValid: IsValidEmail,
+		join:    clientRoute.JoinEmail,
+	},
+	"emojiname": {
+		isValid: func(v string) bool { return IsValidEmojiName(v) == nil },
+		join:    clientRoute.JoinEmojiname,
+	},
+	"segment": {
+		isValid: func(v string) bool { return v != "" && validateStrictSegment(v) == nil },
+		join:    clientRoute.JoinSegment,
+	},
+}
+
+// routeTemplateSegment is either a literal path segment or a named,
+// typed variable, e.g. {team_id:id}.
+type routeTemplateSegment struct {
+	literal   string
+	varName   string
+	validator string
+}
+
+// RouteTemplate is a reversible description of an API v4 route, declared
+// once from a pattern such as
+//
+//	/api/v4/teams/{team_id:id}/channels/{channel_name:channelname}
+//
+// and used both to build concrete URLs (Build) and to match incoming
+// request paths back into their named variables (Match). Each variable
+// is validated using the same rules as the corresponding clientRoute
+// JoinXxx method, so a RouteTemplate can never build or match a path that
+// JoinXxx would otherwise reject. The default segment validator is stricter
+// than JoinSegment: it applies the Strict checks and also rejects empty
+// values, in both Build and Match. Build always builds against a Strict
+// clientRoute, since its variables come from the caller rather than from
+// constant, compile-time-known segments.
+type RouteTemplate struct {
+	pattern  string
+	segments []routeTemplateSegment
+	re       *regexp.Regexp
+}
+
+// NewRouteTemplate parses pattern into a RouteTemplate, compiling the
+// regexp used by Match. It returns an error if pattern references an
+// unknown validator or declares the same variable name twice.
+func NewRouteTemplate(pattern string) (RouteTemplate, error) {
+	parts := strings.Split(strings.Trim(pattern, "/"), "/")
+
+	segments := make([]routeTemplateSegment, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	var reBuilder strings.Builder
+	reBuilder.WriteString("^")
+
+	for _, part := range parts {
+		reBuilder.WriteString("/")
+
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			name, validator, _ := strings.Cut(part[1:len(part)-1], ":")
+			if validator == "" {
+				validator = "segment"
+			}
+
+			if name == "" {
+				return RouteTemplate{}, fmt.Errorf("route template %q has an unnamed variable", pattern)
+			}
+			if _, ok := routeTemplateValidators[validator]; !ok {
+				return RouteTemplate{}, fmt.Errorf("route template %q references unknown validator %q", pattern, validator)
+			}
+			if seen[name] {
+				return RouteTemplate{}, fmt.Errorf("route template %q declares variable %q more than once", pattern, name)
+			}
+			seen[name] = true
+
+			segments = append(segments, routeTemplateSegment{varName: name, validator: validator})
+			fmt.Fprintf(&reBuilder, "(?P<%s>[^/]+)", name)
+			continue
+		}
+
+		segments = append(segments, routeTemplateSegment{literal: part})
+		reBuilder.WriteString(regexp.QuoteMeta(part))
+	}
+	reBuilder.WriteString("$")
+
+	re, err := regexp.Compile(reBuilder.String())
+	if err != nil {
+		return RouteTemplate{}, fmt.Errorf("route template %q produced an invalid regexp: %w", pattern, err)
+	}
+
+	return RouteTemplate{pattern: pattern, segments: segments, re: re}, nil
+}
+
+// Build composes the concrete URL for this template, substituting vars for
+// each named variable. Each variable is first checked against its
+// validator, and then routed through the JoinXxx method matching it, so
+// every value JoinXxx would reject is rejected here too.
+func (t RouteTemplate) Build(vars map[string]string) (string, error) {
+	route := newClientRoute("").Strict()
+
+	for _, seg := range t.segments {
+		if seg.varName == "" {
+			route = route.JoinSegment(seg.literal)
+			continue
+		}
+
+		value, ok := vars[seg.varName]
+		if !ok {
+			return "", fmt.Errorf("route template %q is missing variable %q", t.pattern, seg.varName)
+		}
+
+		validator := routeTemplateValidators[seg.validator]
+		if !validator.isValid(value) {
+			return "", fmt.Errorf("route template %q: %q is not a valid %s", t.pattern, value, seg.validator)
+		}
+
+		route = validator.join(route, value)
+	}
+
+	return route.String()
+}
+
+// Match reports whether path matches this template, returning the named
+// variables captured from it. It returns false if path doesn't match the
+// template's shape, or if any captured variable fails the validator
+// declared for it.
+func (t RouteTemplate) Match(path string) (map[string]string, bool) {
+	groups := t.re.FindStringSubmatch(path)
+	if groups == nil {
+		return nil, false
+	}
+
+	vars := make(map[string]string, len(t.segments))
+	for i, name := range t.re.SubexpNames() {
+		if i == 0 || name == "" {
+			continue
+		}
+		vars[name] = groups[i]
+	}
+
+	for _, seg := range t.segments {
+		if seg.varName == "" {
+			continue
+		}
+		if !routeTemplateValidators[seg.validator].isValid(vars[seg.varName]) {
+			return nil, false
+		}
+	}
+
+	return vars, true
+}
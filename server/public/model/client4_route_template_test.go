@@ -0,0 +1,115 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRouteTemplate(t *testing.T) {
+	t.Run("valid pattern", func(t *testing.T) {
+		_, err := NewRouteTemplate("/api/v4/teams/{team_id:id}/channels/{channel_name:channelname}")
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		_, err := NewRouteTemplate("/api/v4/teams/{team_id:bogus}")
+		require.Error(t, err)
+	})
+
+	t.Run("unnamed variable", func(t *testing.T) {
+		_, err := NewRouteTemplate("/api/v4/teams/{:id}")
+		require.Error(t, err)
+	})
+
+	t.Run("duplicate variable", func(t *testing.T) {
+		_, err := NewRouteTemplate("/api/v4/teams/{team_id:id}/{team_id:id}")
+		require.Error(t, err)
+	})
+
+	t.Run("untyped variable defaults to segment", func(t *testing.T) {
+		_, err := NewRouteTemplate("/api/v4/teams/{team_id}")
+		require.NoError(t, err)
+	})
+}
+
+func TestRouteTemplateBuild(t *testing.T) {
+	tpl, err := NewRouteTemplate("/api/v4/teams/{team_id:id}/channels/{channel_name:channelname}")
+	require.NoError(t, err)
+
+	t.Run("valid vars", func(t *testing.T) {
+		result, err := tpl.Build(map[string]string{
+			"team_id":      "abcdefghijklmnopqrstuvwxyz",
+			"channel_name": "town-square",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "/api/v4/teams/abcdefghijklmnopqrstuvwxyz/channels/town-square", result)
+	})
+
+	t.Run("missing var", func(t *testing.T) {
+		_, err := tpl.Build(map[string]string{"team_id": "abcdefghijklmnopqrstuvwxyz"})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid var", func(t *testing.T) {
+		_, err := tpl.Build(map[string]string{
+			"team_id":      "short",
+			"channel_name": "town-square",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestRouteTemplateMatch(t *testing.T) {
+	tpl, err := NewRouteTemplate("/api/v4/teams/{team_id:id}/channels/{channel_name:channelname}")
+	require.NoError(t, err)
+
+	t.Run("matching path", func(t *testing.T) {
+		vars, ok := tpl.Match("/api/v4/teams/abcdefghijklmnopqrstuvwxyz/channels/town-square")
+		require.True(t, ok)
+		require.Equal(t, map[string]string{
+			"team_id":      "abcdefghijklmnopqrstuvwxyz",
+			"channel_name": "town-square",
+		}, vars)
+	})
+
+	t.Run("wrong shape", func(t *testing.T) {
+		_, ok := tpl.Match("/api/v4/teams/abcdefghijklmnopqrstuvwxyz")
+		require.False(t, ok)
+	})
+
+	t.Run("captured variable fails validation", func(t *testing.T) {
+		_, ok := tpl.Match("/api/v4/teams/short/channels/town-square")
+		require.False(t, ok)
+	})
+}
+
+func TestRouteTemplateDefaultSegmentRejectsTraversal(t *testing.T) {
+	tpl, err := NewRouteTemplate("/api/v4/files/{name}/download")
+	require.NoError(t, err)
+
+	t.Run("Match rejects a dot-dot segment", func(t *testing.T) {
+		_, ok := tpl.Match("/api/v4/files/../download")
+		require.False(t, ok)
+	})
+
+	t.Run("Match rejects a dot segment", func(t *testing.T) {
+		_, ok := tpl.Match("/api/v4/files/./download")
+		require.False(t, ok)
+	})
+
+	t.Run("Build rejects a dot-dot value instead of collapsing the path", func(t *testing.T) {
+		_, err := tpl.Build(map[string]string{"name": ".."})
+		require.Error(t, err)
+	})
+
+	t.Run("Build rejects a dot value", func(t *testing.T) {
+		_, err := tpl.Build(map[string]string{"name": "."})
+		require.Error(t, err)
+	})
+
+	t.Run("Build rejects an empty value instead of collapsing the path", func(t *testing.T) {
+		_, err := tpl.Build(map[string]string{"name": ""})
+		require.Error(t, err)
+	})
+}
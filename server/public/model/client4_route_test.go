@@ -625,6 +625,221 @@ func TestClientRouteErrorPropagation(t *testing.T) {
 	})
 }
 
+func TestClientRouteAddQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		route    clientRoute
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "single query param",
+			route:    newClientRoute("api").AddQuery("since", "1234"),
+			expected: "/api?since=1234",
+			wantErr:  false,
+		},
+		{
+			name:     "multiple query params",
+			route:    newClientRoute("api").AddQuery("since", "1234").AddQuery("page", "2"),
+			expected: "/api?page=2&since=1234",
+			wantErr:  false,
+		},
+		{
+			name:     "value needing escaping",
+			route:    newClientRoute("api").AddQuery("name", "hello world"),
+			expected: "/api?name=hello+world",
+			wantErr:  false,
+		},
+		{
+			name:     "empty key",
+			route:    newClientRoute("api").AddQuery("", "value"),
+			expected: "",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str, err := tt.route.String()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, str)
+			}
+		})
+	}
+}
+
+func TestClientRouteAddQueryValues(t *testing.T) {
+	values := url.Values{}
+	values.Add("page", "2")
+	values.Add("per_page", "60")
+
+	route := newClientRoute("api").JoinSegment("posts").AddQueryValues(values)
+	str, err := route.String()
+	require.NoError(t, err)
+	require.Equal(t, "/api/posts?page=2&per_page=60", str)
+}
+
+func TestClientRouteClearQuery(t *testing.T) {
+	route := newClientRoute("api").AddQuery("since", "1234").ClearQuery()
+	str, err := route.String()
+	require.NoError(t, err)
+	require.Equal(t, "/api", str)
+}
+
+func TestClientRouteSetFragment(t *testing.T) {
+	route := newClientRoute("api").JoinSegment("teams").SetFragment("overview")
+	str, err := route.String()
+	require.NoError(t, err)
+	require.Equal(t, "/api/teams#overview", str)
+}
+
+func TestClientRouteQueryAndFragmentErrorPropagation(t *testing.T) {
+	route := newClientRoute("api").
+		JoinId("invalid-id"). // This will cause an error
+		AddQuery("since", "1234").
+		SetFragment("overview")
+
+	_, err := route.String()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not a valid ID")
+}
+
+func TestAPIRoutePrefixes(t *testing.T) {
+	str, err := APIRoute().String()
+	require.NoError(t, err)
+	require.Equal(t, "/api", str)
+
+	str, err = APIv4Route().String()
+	require.NoError(t, err)
+	require.Equal(t, "/api/v4", str)
+}
+
+func TestClientRoutePrefixReuse(t *testing.T) {
+	// clientRoute methods take a value receiver, so a shared prefix such as
+	// APIv4Route() can be reused as the base of multiple routes directly,
+	// without either call site's JoinXxx calls affecting the other or the
+	// shared base.
+	base := APIv4Route()
+
+	teams := base.JoinSegment("teams")
+	users := base.JoinSegment("users")
+
+	teamsStr, err := teams.String()
+	require.NoError(t, err)
+	require.Equal(t, "/api/v4/teams", teamsStr)
+
+	usersStr, err := users.String()
+	require.NoError(t, err)
+	require.Equal(t, "/api/v4/users", usersStr)
+
+	baseStr, err := base.String()
+	require.NoError(t, err)
+	require.Equal(t, "/api/v4", baseStr, "reusing a route as a prefix must not mutate it")
+}
+
+func TestClientRouteMustString(t *testing.T) {
+	require.Equal(t, "/api/v4/teams", APIv4Route().JoinSegment("teams").MustString())
+
+	require.Panics(t, func() {
+		APIv4Route().JoinId("invalid-id").MustString()
+	})
+}
+
+func TestClientRouteStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		route   clientRoute
+		wantErr bool
+	}{
+		{
+			name:    "ordinary segments are unaffected",
+			route:   newStrictClientRoute("api").JoinSegment("v4").JoinSegment("teams"),
+			wantErr: false,
+		},
+		{
+			name:    "dot segment is rejected",
+			route:   newStrictClientRoute("api").JoinSegment("."),
+			wantErr: true,
+		},
+		{
+			name:    "dot-dot segment is rejected",
+			route:   newStrictClientRoute("api").JoinSegment(".."),
+			wantErr: true,
+		},
+		{
+			name:    "embedded NUL byte is rejected",
+			route:   newStrictClientRoute("api").JoinSegment("team\x00name"),
+			wantErr: true,
+		},
+		{
+			name:    "embedded CR is rejected",
+			route:   newStrictClientRoute("api").JoinSegment("team\rname"),
+			wantErr: true,
+		},
+		{
+			name:    "embedded LF is rejected",
+			route:   newStrictClientRoute("api").JoinSegment("team\nname"),
+			wantErr: true,
+		},
+		{
+			name:    "percent-encoded slash is rejected",
+			route:   newStrictClientRoute("api").JoinSegment("team%2Fname"),
+			wantErr: true,
+		},
+		{
+			name:    "mixed-case percent-encoded slash is rejected",
+			route:   newStrictClientRoute("api").JoinSegment("team%2fname"),
+			wantErr: true,
+		},
+		{
+			name:    "percent-encoded backslash is rejected",
+			route:   newStrictClientRoute("api").JoinSegment("team%5Cname"),
+			wantErr: true,
+		},
+		{
+			name:    "percent-encoded dot-dot is rejected",
+			route:   newStrictClientRoute("api").JoinSegment("%2e%2e"),
+			wantErr: true,
+		},
+		{
+			name:    "percent-encoded dot is rejected",
+			route:   newStrictClientRoute("api").JoinSegment("%2e"),
+			wantErr: true,
+		},
+		{
+			name:    "non-strict route allows dot-dot",
+			route:   newClientRoute("api").JoinSegment(".."),
+			wantErr: false,
+		},
+		{
+			name:    "Strict applied mid-chain still validates later segments",
+			route:   newClientRoute("api").Strict().JoinSegment(".."),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.route.String()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClientRouteNormalize(t *testing.T) {
+	route := clientRoute{url: url.URL{Path: "//api//v4//teams"}, strict: true}
+	str, err := route.String()
+	require.NoError(t, err)
+	require.Equal(t, "/api/v4/teams", str)
+}
+
 func TestClientRouteLeadingSlash(t *testing.T) {
 	tests := []struct {
 		name  string